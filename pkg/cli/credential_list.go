@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gptscript-ai/gptscript/pkg/cache"
+	"github.com/gptscript-ai/gptscript/pkg/config"
+	"github.com/gptscript-ai/gptscript/pkg/credentials"
+	"github.com/spf13/cobra"
+)
+
+type List struct {
+	root *GPTScript
+
+	AllContexts bool `usage:"Show which context each credential resolves from" name:"all-contexts"`
+}
+
+func (c *List) Customize(cmd *cobra.Command) {
+	cmd.Use = "list"
+	cmd.Aliases = []string{"ls"}
+	cmd.SilenceUsage = true
+	cmd.Short = "List stored credentials"
+	cmd.Args = cobra.NoArgs
+}
+
+func (c *List) Run(_ *cobra.Command, _ []string) error {
+	opts, err := c.root.NewGPTScriptOpts()
+	if err != nil {
+		return err
+	}
+	opts.Cache = cache.Complete(opts.Cache)
+
+	cfg, err := config.ReadCLIConfig(c.root.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read CLI config: %w", err)
+	}
+
+	store, err := credentials.NewStore(cfg, c.root.CredentialContext, opts.Cache.CacheDir)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials store: %w", err)
+	}
+
+	if !c.AllContexts {
+		creds, err := store.List()
+		if err != nil {
+			return fmt.Errorf("failed to list credentials: %w", err)
+		}
+		sort.Slice(creds, func(i, j int) bool { return creds[i].ToolName < creds[j].ToolName })
+		for _, cred := range creds {
+			fmt.Println(cred.ToolName)
+		}
+		return nil
+	}
+
+	// store.ListAll walks every configured context in order, so the context
+	// reported for each credential is the one lookups actually resolve from.
+	creds, err := store.ListAll()
+	if err != nil {
+		return fmt.Errorf("failed to list credentials across contexts: %w", err)
+	}
+	sort.Slice(creds, func(i, j int) bool { return creds[i].ToolName < creds[j].ToolName })
+	for _, cred := range creds {
+		fmt.Printf("%s\t%s\n", cred.ToolName, cred.Context)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
 	"sort"
@@ -31,6 +32,7 @@ import (
 	"github.com/gptscript-ai/gptscript/pkg/types"
 	"github.com/gptscript-ai/gptscript/pkg/version"
 	"github.com/gptscript-ai/tui"
+	"github.com/oklog/ulid/v2"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"golang.org/x/term"
@@ -63,7 +65,7 @@ type GPTScript struct {
 	Chdir              string `usage:"Change current working directory" short:"C"`
 	Daemon             bool   `usage:"Run tool as a daemon" local:"true" hidden:"true"`
 	Ports              string `usage:"The port range to use for ephemeral daemon ports (ex: 11000-12000)" hidden:"true"`
-	CredentialContext  string `usage:"Context name in which to store credentials" default:"default"`
+	CredentialContext  string `usage:"Comma-separated list of context names to store/look up credentials in, checked in order (first hit wins for reads, first entry is used for writes)" default:"default"`
 	CredentialOverride string `usage:"Credentials to override (ex: --credential-override github.com/example/cred-tool:API_TOKEN=1234)"`
 	ChatState          string `usage:"The chat state to continue, or null to start a new chat and return the state" local:"true"`
 	ForceChat          bool   `usage:"Force an interactive chat session if even the top level tool is not a chat tool" local:"true"`
@@ -72,6 +74,10 @@ type GPTScript struct {
 	UI                 bool   `usage:"Launch the UI" local:"true" name:"ui"`
 	DisableTUI         bool   `usage:"Don't use chat TUI but instead verbose output" local:"true" name:"disable-tui"`
 	SaveChatStateFile  string `usage:"A file to save the chat state to so that a conversation can be resumed with --chat-state" local:"true"`
+	RunID              string `usage:"An ID to correlate this run's events, sub-tool calls, and log messages (default: generated)" name:"run-id"`
+	MaxEventPayload    int    `usage:"Truncate input/output/content fields in events and logs to this many bytes (0 = unlimited)" name:"max-event-payload-bytes" default:"4096"`
+	ListToolsFormat    string `usage:"Output format for --list-tools: flat, tree, or json" name:"list-tools-format" default:"flat" local:"true"`
+	Tree               bool   `usage:"Shorthand for --list-tools-format=tree" local:"true"`
 
 	readData []byte
 }
@@ -82,6 +88,8 @@ func New() *cobra.Command {
 		root,
 		&Eval{gptscript: root},
 		&Credential{root: root},
+		&Support{root: root},
+		&Setup{root: root},
 		&Parse{},
 		&Fmt{},
 		&SDKServer{
@@ -126,18 +134,27 @@ func New() *cobra.Command {
 }
 
 func (r *GPTScript) NewGPTScriptOpts() (gptscript.Options, error) {
+	if r.RunID == "" {
+		r.RunID = ulid.Make().String()
+	}
+
+	monitorOpts := monitor.Options(r.DisplayOptions)
+	monitorOpts.MaxPayloadBytes = r.MaxEventPayload
+
 	opts := gptscript.Options{
 		Cache:   cache.Options(r.CacheOptions),
 		OpenAI:  openai.Options(r.OpenAIOptions),
-		Monitor: monitor.Options(r.DisplayOptions),
+		Monitor: monitorOpts,
 		Runner: runner.Options{
 			CredentialOverride: r.CredentialOverride,
 			Sequential:         r.ForceSequential,
+			RunID:              r.RunID,
 		},
 		Quiet:             r.Quiet,
 		Env:               os.Environ(),
 		CredentialContext: r.CredentialContext,
 		Workspace:         r.Workspace,
+		RunID:             r.RunID,
 	}
 
 	if r.Confirm {
@@ -162,7 +179,7 @@ func (r *GPTScript) NewGPTScriptOpts() (gptscript.Options, error) {
 	}
 
 	if r.EventsStreamTo != "" {
-		mf, err := monitor.NewFileFactory(r.EventsStreamTo)
+		mf, err := monitor.NewFileFactory(r.EventsStreamTo, monitor.WithRunID(r.RunID), monitor.WithMaxPayloadBytes(r.MaxEventPayload))
 		if err != nil {
 			return gptscript.Options{}, err
 		}
@@ -194,6 +211,20 @@ func (r *GPTScript) Customize(cmd *cobra.Command) {
 }
 
 func (r *GPTScript) listTools(ctx context.Context, gptScript *gptscript.GPTScript, prg types.Program) error {
+	format := r.ListToolsFormat
+	if r.Tree {
+		// --tree is a shorthand for --list-tools-format=tree, and always wins
+		// since --list-tools-format has a non-empty default ("flat").
+		format = "tree"
+	}
+
+	switch format {
+	case "tree":
+		return r.printToolTree(prg)
+	case "json":
+		return r.printToolGraph(prg)
+	}
+
 	tools := gptScript.ListTools(ctx, prg)
 	sort.Slice(tools, func(i, j int) bool {
 		return tools[i].Name < tools[j].Name
@@ -213,6 +244,136 @@ func (r *GPTScript) listTools(ctx context.Context, gptScript *gptscript.GPTScrip
 	return nil
 }
 
+// toolKind describes how a node in --list-tools-format=tree/json should be
+// annotated, mirroring the distinctions the flat listing already makes via
+// tool.String().
+func toolKind(tool types.Tool) string {
+	switch {
+	case tool.IsCredential():
+		return "credential"
+	case tool.IsDaemon():
+		return "daemon"
+	case tool.IsChat():
+		return "chat"
+	default:
+		return "tool"
+	}
+}
+
+// printToolTree renders the program's tool graph as an indented tree rooted
+// at the entrypoint tool, following the same "tools:"/"context:" edges used
+// to resolve the flat listing. Edges that loop back to an ancestor are
+// printed once and marked as a back-reference instead of being expanded
+// again, since the graph is not guaranteed to be acyclic.
+//
+// Untested: exercising the cycle/edge-labeling logic here needs real
+// types.Program/types.Tool fixtures (including IsCredential/IsDaemon/IsChat
+// behavior), which this module doesn't vendor.
+func (r *GPTScript) printToolTree(prg types.Program) error {
+	root, ok := prg.ToolSet[prg.EntryToolID]
+	if !ok {
+		return fmt.Errorf("no entrypoint tool found in program")
+	}
+
+	var walk func(tool types.Tool, prefix, edgeType string, ancestors map[string]bool)
+	walk = func(tool types.Tool, prefix, edgeType string, ancestors map[string]bool) {
+		name := tool.Name
+		if name == "" {
+			name = prg.Name
+		}
+		if edgeType == "" {
+			fmt.Printf("%s%s (%s) [%s]\n", prefix, name, toolKind(tool), tool.Source.Location)
+		} else {
+			fmt.Printf("%s%s: %s (%s) [%s]\n", prefix, edgeType, name, toolKind(tool), tool.Source.Location)
+		}
+
+		ancestors = maps.Clone(ancestors)
+		ancestors[tool.ID] = true
+
+		// "tools:" and "context:" edges are walked separately, each labeled
+		// with the edge type that produced them, per the request.
+		for _, childEdgeType := range []string{"tools", "context"} {
+			ids := append([]string{}, tool.ToolMapping[childEdgeType]...)
+			sort.Strings(ids)
+			for _, id := range ids {
+				child, ok := prg.ToolSet[id]
+				if !ok {
+					continue
+				}
+				if ancestors[child.ID] {
+					fmt.Printf("%s  %s: -> %s (cycle)\n", prefix, childEdgeType, child.Name)
+					continue
+				}
+				walk(child, prefix+"  ", childEdgeType, ancestors)
+			}
+		}
+	}
+
+	walk(root, "", "", map[string]bool{})
+	return nil
+}
+
+type toolGraphNode struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	Location string `json:"location"`
+}
+
+type toolGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+type toolGraph struct {
+	Nodes []toolGraphNode `json:"nodes"`
+	Edges []toolGraphEdge `json:"edges"`
+}
+
+// printToolGraph emits the same tool graph as printToolTree, but as a flat
+// {nodes, edges} structure so IDEs/UIs can render their own tree/graph view.
+func (r *GPTScript) printToolGraph(prg types.Program) error {
+	graph := toolGraph{}
+	for id, tool := range prg.ToolSet {
+		name := tool.Name
+		if name == "" {
+			name = prg.Name
+		}
+		graph.Nodes = append(graph.Nodes, toolGraphNode{
+			ID:       id,
+			Name:     name,
+			Kind:     toolKind(tool),
+			Location: tool.Source.Location,
+		})
+
+		for _, edgeType := range []string{"tools", "context"} {
+			for _, to := range tool.ToolMapping[edgeType] {
+				graph.Edges = append(graph.Edges, toolGraphEdge{
+					From: id,
+					To:   to,
+					Type: edgeType,
+				})
+			}
+		}
+	}
+
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].From != graph.Edges[j].From {
+			return graph.Edges[i].From < graph.Edges[j].From
+		}
+		return graph.Edges[i].To < graph.Edges[j].To
+	})
+
+	data, err := json.MarshalIndent(graph, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
 func (r *GPTScript) PersistentPre(*cobra.Command, []string) error {
 	// chdir as soon as possible
 	if r.Chdir != "" {
@@ -299,6 +460,13 @@ func (r *GPTScript) readProgram(ctx context.Context, runner *gptscript.GPTScript
 	})
 }
 
+func (r *GPTScript) truncatePreview(s string) string {
+	if r.MaxEventPayload <= 0 || len(s) <= r.MaxEventPayload {
+		return s
+	}
+	return fmt.Sprintf("%s...[truncated %d bytes]", s[:r.MaxEventPayload], len(s)-r.MaxEventPayload)
+}
+
 func (r *GPTScript) PrintOutput(toolInput, toolOutput string) (err error) {
 	if r.Output != "" && r.Output != "-" {
 		err = os.WriteFile(r.Output, []byte(toolOutput), 0644)
@@ -309,7 +477,7 @@ func (r *GPTScript) PrintOutput(toolInput, toolOutput string) (err error) {
 		if !*r.Quiet {
 			if toolInput != "" {
 				_, _ = fmt.Fprint(os.Stderr, "\nINPUT:\n\n")
-				_, _ = fmt.Fprintln(os.Stderr, toolInput)
+				_, _ = fmt.Fprintln(os.Stderr, r.truncatePreview(toolInput))
 			}
 			_, _ = fmt.Fprint(os.Stderr, "\nOUTPUT:\n\n")
 		}
@@ -328,6 +496,11 @@ func (r *GPTScript) Run(cmd *cobra.Command, args []string) (retErr error) {
 		return err
 	}
 
+	mvl.SetRunID(gptOpt.RunID)
+	if r.Quiet == nil || !*r.Quiet {
+		fmt.Fprintf(os.Stderr, "RunID: %s\n", gptOpt.RunID)
+	}
+
 	// If the user is trying to launch the chat-builder UI, then set up the tool and options here.
 	if r.UI {
 		args = append([]string{env.VarOrDefault("GPTSCRIPT_CHAT_UI_TOOL", "github.com/gptscript-ai/ui@v2")}, args...)
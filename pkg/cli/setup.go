@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gptscript-ai/gptscript/pkg/builtin"
+	"github.com/gptscript-ai/gptscript/pkg/cache"
+	"github.com/gptscript-ai/gptscript/pkg/config"
+	"github.com/gptscript-ai/gptscript/pkg/credentials"
+	"github.com/gptscript-ai/gptscript/pkg/gptscript"
+	"github.com/gptscript-ai/gptscript/pkg/loader"
+	"github.com/spf13/cobra"
+)
+
+// starterHubTools is installed by default at the end of the setup wizard
+// when the user opts in.
+var starterHubTools = []string{
+	"github.com/gptscript-ai/context",
+}
+
+type Setup struct {
+	root *GPTScript
+
+	Yes          bool   `usage:"Run non-interactively, failing if a required value is missing"`
+	Provider     string `usage:"Default model provider to configure (openai, azure, local)"`
+	APIKey       string `usage:"API key for the chosen provider"`
+	BaseURL      string `usage:"Base URL for the chosen provider (required for azure and local)"`
+	DefaultModel string `usage:"Model to set as the config default" name:"default-model"`
+	SkipHubTools bool   `usage:"Don't offer to install the starter set of hub tools"`
+}
+
+func (s *Setup) Customize(cmd *cobra.Command) {
+	cmd.Use = "setup"
+	cmd.Short = "Interactively configure gptscript for first use"
+	cmd.Args = cobra.NoArgs
+}
+
+func (s *Setup) Run(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.ReadCLIConfig(s.root.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read CLI config: %w", err)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+
+	if !cfg.IsEmpty() && !s.Yes {
+		q := fmt.Sprintf("An existing config was found (default model %q); overwrite it? (y/n)", cfg.DefaultModel)
+		if !strings.EqualFold(prompt(reader, q, "n"), "y") {
+			fmt.Fprintln(os.Stderr, "Setup cancelled.")
+			return nil
+		}
+	}
+
+	provider := s.Provider
+	if provider == "" {
+		if s.Yes {
+			return fmt.Errorf("--provider is required with --yes")
+		}
+		provider = prompt(reader, "Default model provider (openai, azure, local)", "openai")
+	}
+
+	apiKeyRequired := provider != "local"
+	baseURLRequired := provider == "azure" || provider == "local"
+
+	apiKey := s.APIKey
+	if apiKey == "" && apiKeyRequired {
+		if s.Yes {
+			return fmt.Errorf("--api-key is required with --yes for provider %q", provider)
+		}
+		apiKey = prompt(reader, "API key", "")
+	}
+
+	baseURL := s.BaseURL
+	if baseURL == "" && baseURLRequired {
+		if s.Yes {
+			return fmt.Errorf("--base-url is required with --yes for provider %q", provider)
+		}
+		baseURL = prompt(reader, fmt.Sprintf("Base URL for %s", provider), "")
+	} else if baseURL == "" && !s.Yes {
+		baseURL = prompt(reader, "Base URL (leave blank for default)", "")
+	}
+
+	opts, err := s.root.NewGPTScriptOpts()
+	if err != nil {
+		return err
+	}
+	opts.Cache = cache.Complete(opts.Cache)
+
+	store, err := credentials.NewStore(cfg, s.root.CredentialContext, opts.Cache.CacheDir)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials store: %w", err)
+	}
+
+	if env := providerCredentialEnv(provider, apiKey, baseURL); len(env) > 0 {
+		if err := store.Add(cmd.Context(), credentials.Credential{
+			Context:  s.root.CredentialContext,
+			ToolName: provider,
+			Env:      env,
+		}); err != nil {
+			return fmt.Errorf("failed to save credential: %w", err)
+		}
+	}
+
+	defaultModel := s.DefaultModel
+	if defaultModel == "" && !s.Yes {
+		defaultModel = prompt(reader, fmt.Sprintf("Default model (leave blank to keep %q)", builtin.DefaultModel()), "")
+	}
+	if defaultModel != "" {
+		cfg.DefaultModel = defaultModel
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+
+	installHubTools := !s.SkipHubTools
+	if installHubTools && !s.Yes {
+		installHubTools = strings.EqualFold(prompt(reader, "Install the starter set of hub tools? (y/n)", "y"), "y")
+	}
+	if installHubTools {
+		gptScript, err := gptscript.New(&opts)
+		if err != nil {
+			return fmt.Errorf("failed to set up cache for hub tool install: %w", err)
+		}
+		defer gptScript.Close(true)
+
+		for _, tool := range starterHubTools {
+			fmt.Fprintf(os.Stderr, "Installing %s...\n", tool)
+			if _, err := loader.Program(cmd.Context(), tool, "", loader.Options{Cache: gptScript.Cache}); err != nil {
+				return fmt.Errorf("failed to install %s: %w", tool, err)
+			}
+		}
+	}
+
+	fmt.Println("\nSetup complete. To reproduce this configuration non-interactively, run:")
+	args := []string{"gptscript", "setup", "--yes", "--provider=" + provider}
+	if s.root.CredentialContext != "" && s.root.CredentialContext != "default" {
+		args = append(args, "--credential-context="+s.root.CredentialContext)
+	}
+	if apiKey != "" {
+		args = append(args, "--api-key=<your-api-key>")
+	}
+	if baseURL != "" {
+		args = append(args, "--base-url="+baseURL)
+	}
+	if defaultModel != "" {
+		args = append(args, "--default-model="+defaultModel)
+	}
+	if !installHubTools {
+		args = append(args, "--skip-hub-tools")
+	}
+	fmt.Println(strings.Join(args, " "))
+
+	return nil
+}
+
+// providerCredentialEnv maps the collected API key/base URL to the env vars
+// each provider actually reads them from.
+func providerCredentialEnv(provider, apiKey, baseURL string) map[string]string {
+	env := map[string]string{}
+	switch provider {
+	case "azure":
+		if apiKey != "" {
+			env["AZURE_OPENAI_API_KEY"] = apiKey
+		}
+		if baseURL != "" {
+			env["AZURE_OPENAI_ENDPOINT"] = baseURL
+		}
+	default:
+		if apiKey != "" {
+			env["OPENAI_API_KEY"] = apiKey
+		}
+		if baseURL != "" {
+			env["OPENAI_BASE_URL"] = baseURL
+		}
+	}
+	return env
+}
+
+func prompt(reader *bufio.Reader, question, def string) string {
+	if def != "" {
+		fmt.Fprintf(os.Stderr, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(os.Stderr, "%s: ", question)
+	}
+	line, _ := reader.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return def
+	}
+	return line
+}
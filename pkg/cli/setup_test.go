@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProviderCredentialEnv(t *testing.T) {
+	cases := []struct {
+		name     string
+		provider string
+		apiKey   string
+		baseURL  string
+		want     map[string]string
+	}{
+		{"openai full", "openai", "sk-1", "https://api.example.com", map[string]string{
+			"OPENAI_API_KEY":  "sk-1",
+			"OPENAI_BASE_URL": "https://api.example.com",
+		}},
+		{"openai key only", "openai", "sk-1", "", map[string]string{
+			"OPENAI_API_KEY": "sk-1",
+		}},
+		{"azure full", "azure", "az-1", "https://example.openai.azure.com", map[string]string{
+			"AZURE_OPENAI_API_KEY":  "az-1",
+			"AZURE_OPENAI_ENDPOINT": "https://example.openai.azure.com",
+		}},
+		{"local base url only", "local", "", "http://localhost:8080", map[string]string{
+			"OPENAI_BASE_URL": "http://localhost:8080",
+		}},
+		{"nothing collected", "openai", "", "", map[string]string{}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := providerCredentialEnv(c.provider, c.apiKey, c.baseURL)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("providerCredentialEnv(%q, %q, %q) = %v, want %v", c.provider, c.apiKey, c.baseURL, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+package cli
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	cmdpkg "github.com/acorn-io/cmd"
+	"github.com/gptscript-ai/gptscript/pkg/builtin"
+	"github.com/gptscript-ai/gptscript/pkg/cache"
+	"github.com/gptscript-ai/gptscript/pkg/config"
+	"github.com/gptscript-ai/gptscript/pkg/credentials"
+	"github.com/gptscript-ai/gptscript/pkg/mvl"
+	"github.com/gptscript-ai/gptscript/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// secretLikeEnvVar matches environment variable names that commonly carry
+// secrets, so they can be scrubbed from a support bundle by default.
+var secretLikeEnvVar = regexp.MustCompile(`(?i)(KEY|TOKEN|SECRET|PASSWORD|CREDENTIAL)`)
+
+// redactor pairs a pattern with the replacement template used to scrub it,
+// since the JSON shape needs its closing quote put back and the others
+// don't.
+type redactor struct {
+	pattern *regexp.Regexp
+	repl    string
+}
+
+// defaultRedactors are always applied to bundle contents, in addition to any
+// --redact-extra patterns, so a support dump never ships a secret by default.
+// They target the common "<key-ish-json-field>": "<value>" and
+// --flag=tool:VAR=value shapes that API keys and credential overrides take
+// in this codebase.
+var defaultRedactors = []redactor{
+	{regexp.MustCompile(`(?i)("[^"]*(?:key|token|secret|password)[^"]*"\s*:\s*)"[^"]*"`), `${1}"[REDACTED]"`},
+	{regexp.MustCompile(`(?i)((?:api[-_]?key|token|secret|password)\s*[:=]\s*)\S+`), "${1}[REDACTED]"},
+	{regexp.MustCompile(`(?i)(--credential-override[= ]\S+?[:=])[^\s,]+`), "${1}[REDACTED]"},
+}
+
+type Support struct {
+	root *GPTScript
+}
+
+func (s *Support) Customize(cmd *cobra.Command) {
+	cmd.Use = "support"
+	cmd.Short = "Commands for gathering information to support a bug report"
+	cmd.Args = cobra.NoArgs
+	cmd.AddCommand(cmdpkg.Command(&SupportDump{root: s.root}))
+}
+
+func (s *Support) Run(cmd *cobra.Command, _ []string) error {
+	return cmd.Help()
+}
+
+type SupportDump struct {
+	root *GPTScript
+
+	Output           string   `usage:"File to write the support bundle to, or - for stdout" default:"support-bundle.zip"`
+	RedactExtra      []string `usage:"Additional regex patterns to redact from the bundle"`
+	IncludeCache     bool     `usage:"Include the contents of the cache directory in the bundle"`
+	IncludeWorkspace bool     `usage:"Include the contents of the workspace directory in the bundle"`
+	LogLines         int      `usage:"Number of trailing debug log lines to include" default:"500"`
+}
+
+func (s *SupportDump) Customize(cmd *cobra.Command) {
+	cmd.Use = "dump"
+	cmd.Short = "Gather a redacted diagnostic bundle to attach to a bug report"
+	cmd.Args = cobra.NoArgs
+}
+
+func (s *SupportDump) Run(_ *cobra.Command, _ []string) (err error) {
+	redactors, err := compileRedactors(s.RedactExtra)
+	if err != nil {
+		return fmt.Errorf("failed to compile --redact-extra pattern: %w", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if s.Output != "" && s.Output != "-" {
+		f, err := os.Create(s.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", s.Output, err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	zw := zip.NewWriter(out)
+	defer func() {
+		if cerr := zw.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to finalize support bundle: %w", cerr)
+		}
+	}()
+
+	opts, err := s.root.NewGPTScriptOpts()
+	if err != nil {
+		return err
+	}
+	opts.Cache = cache.Complete(opts.Cache)
+
+	if err := writeZipJSON(zw, "version.json", version.Get()); err != nil {
+		return err
+	}
+
+	if err := writeZipJSON(zw, "flags.json", redactedFlags(s.root)); err != nil {
+		return err
+	}
+
+	cfg, err := config.ReadCLIConfig(s.root.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read CLI config: %w", err)
+	}
+	if err := writeZipText(zw, "config.json", redact(toJSON(cfg), redactors)); err != nil {
+		return err
+	}
+
+	store, err := credentials.NewStore(cfg, s.root.CredentialContext, opts.Cache.CacheDir)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials store: %w", err)
+	}
+	creds, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list credentials: %w", err)
+	}
+	sort.Slice(creds, func(i, j int) bool { return creds[i].ToolName < creds[j].ToolName })
+	var credLines []string
+	for _, c := range creds {
+		credLines = append(credLines, fmt.Sprintf("%s\t%s", c.Context, c.ToolName))
+	}
+	if err := writeZipText(zw, "credentials.txt", strings.Join(credLines, "\n")); err != nil {
+		return err
+	}
+
+	cacheStats, err := dirStats(opts.Cache.CacheDir)
+	if err != nil {
+		return fmt.Errorf("failed to stat cache dir: %w", err)
+	}
+	if err := writeZipText(zw, "cache-stats.txt", cacheStats); err != nil {
+		return err
+	}
+
+	if err := writeZipText(zw, "builtin-tools.txt", strings.Join(builtin.ListTools(), "\n")); err != nil {
+		return err
+	}
+
+	// There is no separate hub-tool registry to enumerate here: installed
+	// tool sources are cached as ordinary entries under the cache directory
+	// (see cache-stats.txt, and cache/ when --include-cache is set), and
+	// this command isn't given a target script to resolve an in-use set
+	// from. Note that explicitly rather than silently omitting the file.
+	if err := writeZipText(zw, "hub-tools.txt", "no target script was given to this command, so installed hub/tool metadata isn't resolved here; see cache-stats.txt and --include-cache instead"); err != nil {
+		return err
+	}
+
+	logTail := mvl.TailDebugLog(s.LogLines)
+	if err := writeZipText(zw, "debug-log.txt", redact(logTail, redactors)); err != nil {
+		return err
+	}
+
+	if err := writeZipText(zw, "environment.txt", redact(filteredEnviron(), redactors)); err != nil {
+		return err
+	}
+
+	if s.IncludeCache {
+		if err := addDirToZip(zw, "cache/", opts.Cache.CacheDir); err != nil {
+			return fmt.Errorf("failed to bundle cache directory: %w", err)
+		}
+	}
+
+	if s.IncludeWorkspace && s.root.Workspace != "" {
+		if err := addDirToZip(zw, "workspace/", s.root.Workspace); err != nil {
+			return fmt.Errorf("failed to bundle workspace directory: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// supportFlags is an explicit allow-list of GPTScript flag values that are
+// safe to ship in a support bundle. Anything that can carry a secret
+// (CredentialOverride, the OpenAI/cache API-key flags, etc.) is deliberately
+// left out rather than relying on redaction to catch it after the fact.
+type supportFlags struct {
+	Debug             bool   `json:"debug"`
+	NoTrunc           bool   `json:"noTrunc"`
+	Output            string `json:"output"`
+	EventsStreamTo    string `json:"eventsStreamTo"`
+	SubTool           string `json:"subTool"`
+	ListModels        bool   `json:"listModels"`
+	ListTools         bool   `json:"listTools"`
+	ListToolsFormat   string `json:"listToolsFormat"`
+	Server            bool   `json:"server"`
+	ListenAddress     string `json:"listenAddress"`
+	Daemon            bool   `json:"daemon"`
+	Ports             string `json:"ports"`
+	CredentialContext string `json:"credentialContext"`
+	ForceChat         bool   `json:"forceChat"`
+	ForceSequential   bool   `json:"forceSequential"`
+	Workspace         string `json:"workspace"`
+	UI                bool   `json:"ui"`
+	DisableTUI        bool   `json:"disableTUI"`
+	RunID             string `json:"runID"`
+	MaxEventPayload   int    `json:"maxEventPayloadBytes"`
+}
+
+func redactedFlags(r *GPTScript) supportFlags {
+	return supportFlags{
+		Debug:             r.Debug,
+		NoTrunc:           r.NoTrunc,
+		Output:            r.Output,
+		EventsStreamTo:    r.EventsStreamTo,
+		SubTool:           r.SubTool,
+		ListModels:        r.ListModels,
+		ListTools:         r.ListTools,
+		ListToolsFormat:   r.ListToolsFormat,
+		Server:            r.Server,
+		ListenAddress:     r.ListenAddress,
+		Daemon:            r.Daemon,
+		Ports:             r.Ports,
+		CredentialContext: r.CredentialContext,
+		ForceChat:         r.ForceChat,
+		ForceSequential:   r.ForceSequential,
+		Workspace:         r.Workspace,
+		UI:                r.UI,
+		DisableTUI:        r.DisableTUI,
+		RunID:             r.RunID,
+		MaxEventPayload:   r.MaxEventPayload,
+	}
+}
+
+func compileRedactors(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+func redact(s string, extra []*regexp.Regexp) string {
+	for _, r := range defaultRedactors {
+		s = r.pattern.ReplaceAllString(s, r.repl)
+	}
+	for _, re := range extra {
+		s = re.ReplaceAllString(s, "[REDACTED]")
+	}
+	return s
+}
+
+func filteredEnviron() string {
+	var lines []string
+	for _, kv := range os.Environ() {
+		k, _, _ := strings.Cut(kv, "=")
+		if secretLikeEnvVar.MatchString(k) {
+			lines = append(lines, k+"=[REDACTED]")
+			continue
+		}
+		lines = append(lines, kv)
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+func dirStats(dir string) (string, error) {
+	var count int
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.IsDir() {
+			count++
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("dir=%s files=%d bytes=%d", dir, count, size), nil
+}
+
+func toJSON(v any) string {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("failed to marshal: %v", err)
+	}
+	return string(data)
+}
+
+func writeZipJSON(zw *zip.Writer, name string, v any) error {
+	return writeZipText(zw, name, toJSON(v))
+}
+
+func writeZipText(zw *zip.Writer, name, content string) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
+
+func addDirToZip(zw *zip.Writer, prefix, dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w, err := zw.Create(filepath.Join(prefix, rel))
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, f)
+		return err
+	})
+}
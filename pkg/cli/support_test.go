@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRedactJSONKeyValuePreservesValidJSON(t *testing.T) {
+	in := `{"defaultModel":"gpt-4","envs":{"OPENAI_API_KEY":"sk-super-secret"}}`
+
+	out := redact(in, nil)
+
+	if strings.Contains(out, "sk-super-secret") {
+		t.Fatalf("redact(%q) = %q, still contains the secret", in, out)
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("redact produced invalid JSON: %v\noutput: %s", err, out)
+	}
+}
+
+func TestRedactKeyValuePairs(t *testing.T) {
+	in := "api_key=sk-abc123 token: xyz789"
+	out := redact(in, nil)
+	if strings.Contains(out, "sk-abc123") || strings.Contains(out, "xyz789") {
+		t.Errorf("redact(%q) = %q, want both secrets scrubbed", in, out)
+	}
+}
+
+func TestRedactCredentialOverride(t *testing.T) {
+	in := "--credential-override github.com/example/tool:API_TOKEN=s3cr3t"
+	out := redact(in, nil)
+	if strings.Contains(out, "s3cr3t") {
+		t.Errorf("redact(%q) = %q, want the override value scrubbed", in, out)
+	}
+}
+
+func TestRedactExtraPatterns(t *testing.T) {
+	extra, err := compileRedactors([]string{`custom-[a-z]+`})
+	if err != nil {
+		t.Fatalf("compileRedactors: %v", err)
+	}
+	out := redact("value=custom-secret", extra)
+	if strings.Contains(out, "custom-secret") {
+		t.Errorf("redact with --redact-extra pattern = %q, want it scrubbed", out)
+	}
+}
+
+func TestFilteredEnvironRedactsSecretLikeNames(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-should-not-appear")
+	t.Setenv("SUPPORT_TEST_PLAIN_VAR", "plain-value-should-appear")
+
+	out := filteredEnviron()
+
+	if strings.Contains(out, "sk-should-not-appear") {
+		t.Errorf("filteredEnviron() leaked OPENAI_API_KEY's value")
+	}
+	if !strings.Contains(out, "OPENAI_API_KEY=[REDACTED]") {
+		t.Errorf("filteredEnviron() = %q, want OPENAI_API_KEY redacted by name", out)
+	}
+	if !strings.Contains(out, "SUPPORT_TEST_PLAIN_VAR=plain-value-should-appear") {
+		t.Errorf("filteredEnviron() dropped a non-secret-looking var")
+	}
+}
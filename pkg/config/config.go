@@ -0,0 +1,78 @@
+// Package config reads and writes the gptscript CLI config file, the small
+// bit of user-level state (default model, per-tool env overrides) that isn't
+// a credential and so doesn't belong in the credential store.
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// CLIConfig is the on-disk shape of the CLI config file.
+type CLIConfig struct {
+	DefaultModel string            `json:"defaultModel,omitempty"`
+	Envs         map[string]string `json:"envs,omitempty"`
+
+	path string
+}
+
+// DefaultConfigFile returns the config file path used when none is given
+// explicitly via --config-file.
+func DefaultConfigFile() (string, error) {
+	home, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "gptscript", "config.json"), nil
+}
+
+// ReadCLIConfig reads the CLI config from file, or from DefaultConfigFile if
+// file is empty. A missing file is not an error: it returns a zero-value
+// config that Save will later create.
+func ReadCLIConfig(file string) (*CLIConfig, error) {
+	if file == "" {
+		var err error
+		file, err = DefaultConfigFile()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cfg := &CLIConfig{path: file}
+
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	cfg.path = file
+
+	return cfg, nil
+}
+
+// IsEmpty reports whether c has no configured default model or env
+// overrides, i.e. it's the zero-value config ReadCLIConfig returns for a
+// file that doesn't exist yet.
+func (c *CLIConfig) IsEmpty() bool {
+	return c.DefaultModel == "" && len(c.Envs) == 0
+}
+
+// Save writes the config back to the file it was read from.
+func (c *CLIConfig) Save() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path, data, 0600)
+}
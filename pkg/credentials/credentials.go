@@ -0,0 +1,187 @@
+// Package credentials implements gptscript's on-disk credential store.
+// Credentials are looked up by tool name within one or more "contexts",
+// which let a user layer a shared/team context under a personal one without
+// editing config each time.
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gptscript-ai/gptscript/pkg/config"
+)
+
+// Credential is a single stored credential. Env holds the secret values, so
+// callers must never print it wholesale (e.g. in a support bundle).
+type Credential struct {
+	Context  string            `json:"context"`
+	ToolName string            `json:"toolName"`
+	Env      map[string]string `json:"env"`
+}
+
+// Store resolves credentials across one or more contexts, checked in order:
+// reads return the first hit, writes always go to the first context.
+type Store struct {
+	contexts []string
+	cacheDir string
+}
+
+// NewStore builds a Store from a comma-separated list of context names,
+// e.g. "personal,team". A single bare name is also accepted. The first
+// context listed is authoritative for writes.
+func NewStore(_ *config.CLIConfig, credentialContexts string, cacheDir string) (*Store, error) {
+	contexts := splitContexts(credentialContexts)
+	if len(contexts) == 0 {
+		contexts = []string{"default"}
+	}
+	if cacheDir == "" {
+		return nil, fmt.Errorf("credentials: cache directory is required")
+	}
+	return &Store{contexts: contexts, cacheDir: cacheDir}, nil
+}
+
+func splitContexts(raw string) []string {
+	var contexts []string
+	for _, c := range strings.Split(raw, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			contexts = append(contexts, c)
+		}
+	}
+	return contexts
+}
+
+// Contexts returns the configured lookup order.
+func (s *Store) Contexts() []string {
+	return append([]string{}, s.contexts...)
+}
+
+func (s *Store) file(credContext string) string {
+	return filepath.Join(s.cacheDir, "credentials", credContext+".json")
+}
+
+func (s *Store) load(credContext string) (map[string]Credential, error) {
+	creds := map[string]Credential{}
+
+	data, err := os.ReadFile(s.file(credContext))
+	if os.IsNotExist(err) {
+		return creds, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, err
+	}
+	return creds, nil
+}
+
+func (s *Store) save(credContext string, creds map[string]Credential) error {
+	file := s.file(credContext)
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0600)
+}
+
+// Get walks the configured contexts in order and returns the first
+// credential found for toolName.
+func (s *Store) Get(toolName string) (*Credential, error) {
+	for _, credContext := range s.contexts {
+		creds, err := s.load(credContext)
+		if err != nil {
+			return nil, err
+		}
+		if cred, ok := creds[toolName]; ok {
+			return &cred, nil
+		}
+	}
+	return nil, nil
+}
+
+// Add writes cred to the first configured context, regardless of how many
+// contexts are configured for reads.
+func (s *Store) Add(_ context.Context, cred Credential) error {
+	credContext := s.contexts[0]
+	creds, err := s.load(credContext)
+	if err != nil {
+		return err
+	}
+
+	cred.Context = credContext
+	creds[cred.ToolName] = cred
+
+	return s.save(credContext, creds)
+}
+
+// Remove deletes toolName from the store. Because a delete against the
+// "wrong" context of a stack would silently no-op, Remove requires exactly
+// one configured context; callers should prompt the user to disambiguate
+// with --credential-context otherwise.
+func (s *Store) Remove(toolName string) error {
+	if len(s.contexts) != 1 {
+		return fmt.Errorf("more than one credential context is configured (%s); specify which one to delete from with --credential-context", strings.Join(s.contexts, ", "))
+	}
+
+	credContext := s.contexts[0]
+	creds, err := s.load(credContext)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := creds[toolName]; !ok {
+		return fmt.Errorf("credential %q not found in context %q", toolName, credContext)
+	}
+
+	delete(creds, toolName)
+	return s.save(credContext, creds)
+}
+
+// List returns every credential stored in the first configured context,
+// sorted by the caller if needed.
+func (s *Store) List() ([]Credential, error) {
+	creds, err := s.load(s.contexts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Credential, 0, len(creds))
+	for _, cred := range creds {
+		result = append(result, cred)
+	}
+	return result, nil
+}
+
+// ListAll walks every configured context and returns, for each distinct
+// tool name, the credential and the context it actually resolves from
+// (the first context in the stack that has it) - used by
+// `gptscript credential list --all-contexts`.
+func (s *Store) ListAll() ([]Credential, error) {
+	seen := map[string]bool{}
+	var result []Credential
+
+	for _, credContext := range s.contexts {
+		creds, err := s.load(credContext)
+		if err != nil {
+			return nil, err
+		}
+		for name, cred := range creds {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			cred.Context = credContext
+			result = append(result, cred)
+		}
+	}
+
+	return result, nil
+}
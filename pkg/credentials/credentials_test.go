@@ -0,0 +1,126 @@
+package credentials
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSplitContexts(t *testing.T) {
+	cases := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"default", []string{"default"}},
+		{"personal,team", []string{"personal", "team"}},
+		{" personal , team ,", []string{"personal", "team"}},
+	}
+	for _, c := range cases {
+		if got := splitContexts(c.in); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("splitContexts(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestStoreGetFirstHitWins(t *testing.T) {
+	dir := t.TempDir()
+
+	team, err := NewStore(nil, "team", dir)
+	if err != nil {
+		t.Fatalf("NewStore(team): %v", err)
+	}
+	if err := team.Add(context.Background(), Credential{ToolName: "openai", Env: map[string]string{"OPENAI_API_KEY": "team-key"}}); err != nil {
+		t.Fatalf("Add to team: %v", err)
+	}
+
+	personal, err := NewStore(nil, "personal", dir)
+	if err != nil {
+		t.Fatalf("NewStore(personal): %v", err)
+	}
+	if err := personal.Add(context.Background(), Credential{ToolName: "openai", Env: map[string]string{"OPENAI_API_KEY": "personal-key"}}); err != nil {
+		t.Fatalf("Add to personal: %v", err)
+	}
+	if err := personal.Add(context.Background(), Credential{ToolName: "anthropic", Env: map[string]string{"ANTHROPIC_API_KEY": "personal-anthropic"}}); err != nil {
+		t.Fatalf("Add to personal: %v", err)
+	}
+
+	stacked, err := NewStore(nil, "personal,team", dir)
+	if err != nil {
+		t.Fatalf("NewStore(personal,team): %v", err)
+	}
+
+	cred, err := stacked.Get("openai")
+	if err != nil {
+		t.Fatalf("Get(openai): %v", err)
+	}
+	if cred.Env["OPENAI_API_KEY"] != "personal-key" {
+		t.Errorf("Get(openai) resolved %q, want the personal context's value since it's listed first", cred.Env["OPENAI_API_KEY"])
+	}
+
+	cred, err = stacked.Get("anthropic")
+	if err != nil {
+		t.Fatalf("Get(anthropic): %v", err)
+	}
+	if cred.Env["ANTHROPIC_API_KEY"] != "personal-anthropic" {
+		t.Errorf("Get(anthropic) = %v, want the only context that has it", cred.Env)
+	}
+}
+
+func TestStoreAddWritesFirstContextOnly(t *testing.T) {
+	dir := t.TempDir()
+
+	stacked, err := NewStore(nil, "personal,team", dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if err := stacked.Add(context.Background(), Credential{ToolName: "openai", Env: map[string]string{"OPENAI_API_KEY": "k"}}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	team, err := NewStore(nil, "team", dir)
+	if err != nil {
+		t.Fatalf("NewStore(team): %v", err)
+	}
+	if cred, err := team.Get("openai"); err != nil {
+		t.Fatalf("Get(openai) on team context: %v", err)
+	} else if cred != nil {
+		t.Errorf("Get(openai) on team context = %v, want nil since Add should only write the first configured context", cred)
+	}
+
+	personal, err := NewStore(nil, "personal", dir)
+	if err != nil {
+		t.Fatalf("NewStore(personal): %v", err)
+	}
+	if _, err := personal.Get("openai"); err != nil {
+		t.Errorf("Get(openai) on personal context: %v", err)
+	}
+}
+
+func TestStoreRemoveRequiresSingleContext(t *testing.T) {
+	dir := t.TempDir()
+
+	stacked, err := NewStore(nil, "personal,team", dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if err := stacked.Remove("openai"); err == nil {
+		t.Error("Remove with multiple configured contexts succeeded, want an error telling the caller to pick one with --credential-context")
+	}
+
+	single, err := NewStore(nil, "personal", dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if err := single.Add(context.Background(), Credential{ToolName: "openai", Env: map[string]string{"OPENAI_API_KEY": "k"}}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := single.Remove("openai"); err != nil {
+		t.Errorf("Remove with a single configured context: %v", err)
+	}
+	if cred, err := single.Get("openai"); err != nil {
+		t.Fatalf("Get(openai) after Remove: %v", err)
+	} else if cred != nil {
+		t.Errorf("Get(openai) after Remove = %v, want nil", cred)
+	}
+}
@@ -0,0 +1,127 @@
+// Package monitor emits run events - the same payloads logged to the debug
+// log - to a file, file descriptor, or named pipe so external tooling can
+// follow a run live via --events-stream-to.
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Options controls how events are rendered and truncated before they reach
+// a consumer.
+type Options struct {
+	// MaxPayloadBytes caps the size of the Input/Output/Content fields on
+	// emitted events before they are truncated with a
+	// "...[truncated N bytes]" marker. 0 means unlimited.
+	MaxPayloadBytes int
+}
+
+// Event is a single run event written to an EventsStreamTo destination.
+type Event struct {
+	RunID   string `json:"runID,omitempty"`
+	Type    string `json:"type"`
+	Input   string `json:"input,omitempty"`
+	Output  string `json:"output,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// Factory produces the destination events are written to.
+type Factory interface {
+	WriteEvent(Event) error
+	Close() error
+}
+
+// Option configures a Factory constructed by NewFileFactory.
+type Option func(*fileFactory)
+
+// WithRunID stamps every event written by the factory with runID, so a
+// consumer following several concurrent runs can tell them apart.
+func WithRunID(runID string) Option {
+	return func(f *fileFactory) {
+		f.runID = runID
+	}
+}
+
+// WithMaxPayloadBytes truncates Input/Output/Content on every event to at
+// most max bytes (0 = unlimited) before it is written.
+func WithMaxPayloadBytes(max int) Option {
+	return func(f *fileFactory) {
+		f.maxPayloadBytes = max
+	}
+}
+
+type fileFactory struct {
+	out             *os.File
+	closeOut        bool
+	runID           string
+	maxPayloadBytes int
+}
+
+// NewFileFactory opens dest - a filename, "-" for stdout, or an already-open
+// handle referenced as fd://N - and returns a Factory that appends one JSON
+// event per line.
+func NewFileFactory(dest string, opts ...Option) (Factory, error) {
+	f := &fileFactory{}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	switch dest {
+	case "-", "fd://1":
+		f.out = os.Stdout
+	case "fd://2":
+		f.out = os.Stderr
+	default:
+		if fdStr, ok := strings.CutPrefix(dest, "fd://"); ok {
+			fd, err := strconv.Atoi(fdStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid file descriptor %s: %w", dest, err)
+			}
+			f.out = os.NewFile(uintptr(fd), dest)
+			break
+		}
+		out, err := os.OpenFile(dest, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("opening %s: %w", dest, err)
+		}
+		f.out = out
+		f.closeOut = true
+	}
+
+	return f, nil
+}
+
+func (f *fileFactory) WriteEvent(event Event) error {
+	event.RunID = f.runID
+	event.Input = truncate(event.Input, f.maxPayloadBytes)
+	event.Output = truncate(event.Output, f.maxPayloadBytes)
+	event.Content = truncate(event.Content, f.maxPayloadBytes)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = f.out.Write(append(data, '\n'))
+	return err
+}
+
+func (f *fileFactory) Close() error {
+	if f.closeOut {
+		return f.out.Close()
+	}
+	return nil
+}
+
+// truncate caps s at max bytes (0 = unlimited), appending a marker noting
+// how much was cut so a reader knows the payload was shortened rather than
+// naturally short.
+func truncate(s string, max int) string {
+	if max <= 0 || len(s) <= max {
+		return s
+	}
+	return fmt.Sprintf("%s...[truncated %d bytes]", s[:max], len(s)-max)
+}
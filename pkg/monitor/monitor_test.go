@@ -0,0 +1,65 @@
+package monitor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	cases := []struct {
+		name string
+		s    string
+		max  int
+		want string
+	}{
+		{"unlimited", "hello world", 0, "hello world"},
+		{"under limit", "hello", 10, "hello"},
+		{"at limit", "hello", 5, "hello"},
+		{"over limit", "hello world", 5, "hello...[truncated 6 bytes]"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := truncate(c.s, c.max); got != c.want {
+				t.Errorf("truncate(%q, %d) = %q, want %q", c.s, c.max, got, c.want)
+			}
+		})
+	}
+}
+
+func TestFileFactoryWriteEventTruncatesAndStampsRunID(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "events.jsonl")
+
+	f, err := NewFileFactory(dest, WithRunID("run-123"), WithMaxPayloadBytes(5))
+	if err != nil {
+		t.Fatalf("NewFileFactory: %v", err)
+	}
+	if err := f.WriteEvent(Event{Type: "call", Input: "0123456789", Output: "ok"}); err != nil {
+		t.Fatalf("WriteEvent: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	var got Event
+	if err := json.Unmarshal(data[:len(data)-1], &got); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", data, err)
+	}
+
+	if got.RunID != "run-123" {
+		t.Errorf("RunID = %q, want %q", got.RunID, "run-123")
+	}
+	if !strings.HasPrefix(got.Input, "01234...[truncated") {
+		t.Errorf("Input = %q, want it truncated to 5 bytes with a marker", got.Input)
+	}
+	if got.Output != "ok" {
+		t.Errorf("Output = %q, want it left alone since it's under the limit", got.Output)
+	}
+}
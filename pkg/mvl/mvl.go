@@ -0,0 +1,129 @@
+// Package mvl is gptscript's minimal logging facade: a small global sink
+// that every command-level log call writes through, so flags like --debug
+// and --run-id can change its behavior without threading a logger instance
+// through every caller.
+package mvl
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const ringSize = 2000
+
+var (
+	mu      sync.Mutex
+	debugOn bool
+	simple  = true
+	quiet   bool
+	runID   string
+	ring    []string
+)
+
+// SetDebug enables debug-level output.
+func SetDebug() {
+	mu.Lock()
+	defer mu.Unlock()
+	debugOn = true
+}
+
+// SetSimpleFormat toggles the short, truncated line format used by default;
+// pass false (via --no-trunc) to print log lines in full.
+func SetSimpleFormat(on bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	simple = on
+}
+
+// SetError silences info-level output on stderr, used in --quiet mode.
+// Debugf output is governed only by SetDebug.
+func SetError() {
+	mu.Lock()
+	defer mu.Unlock()
+	quiet = true
+}
+
+// SetRunID stamps every subsequent log line with id, so operators streaming
+// logs from concurrent runs of the daemon/server can correlate a line back
+// to one run.
+func SetRunID(id string) {
+	mu.Lock()
+	defer mu.Unlock()
+	runID = id
+}
+
+func formatLine(level, format string, args ...any) string {
+	mu.Lock()
+	id, trunc := runID, simple
+	mu.Unlock()
+
+	msg := fmt.Sprintf(format, args...)
+	if trunc && len(msg) > 2000 {
+		msg = msg[:2000] + "...[truncated]"
+	}
+
+	ts := time.Now().Format(time.RFC3339)
+	if id != "" {
+		return fmt.Sprintf("%s [run=%s] %s: %s", ts, id, level, msg)
+	}
+	return fmt.Sprintf("%s %s: %s", ts, level, msg)
+}
+
+func record(line string) {
+	mu.Lock()
+	defer mu.Unlock()
+	ring = append(ring, line)
+	if len(ring) > ringSize {
+		ring = ring[len(ring)-ringSize:]
+	}
+}
+
+// Infof logs at info level, suppressed by SetError.
+func Infof(format string, args ...any) {
+	mu.Lock()
+	suppressed := quiet
+	mu.Unlock()
+
+	line := formatLine("INFO", format, args...)
+	record(line)
+	if !suppressed {
+		fmt.Fprintln(os.Stderr, line)
+	}
+}
+
+// Debugf logs at debug level, only emitted once SetDebug has been called.
+func Debugf(format string, args ...any) {
+	mu.Lock()
+	on := debugOn
+	mu.Unlock()
+	if !on {
+		return
+	}
+
+	line := formatLine("DEBUG", format, args...)
+	record(line)
+	fmt.Fprintln(os.Stderr, line)
+}
+
+// Errorf logs at error level; never suppressed.
+func Errorf(format string, args ...any) {
+	line := formatLine("ERROR", format, args...)
+	record(line)
+	fmt.Fprintln(os.Stderr, line)
+}
+
+// TailDebugLog returns the last n recorded log lines (newest last), for
+// inclusion in a support bundle. n <= 0 returns everything buffered.
+func TailDebugLog(n int) string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	lines := ring
+	if n > 0 && n < len(lines) {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
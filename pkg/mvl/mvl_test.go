@@ -0,0 +1,74 @@
+package mvl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// resetState puts the package-level globals back to their initial state so
+// tests don't leak into each other through the shared ring buffer.
+func resetState() {
+	mu.Lock()
+	defer mu.Unlock()
+	debugOn = false
+	simple = true
+	quiet = false
+	runID = ""
+	ring = nil
+}
+
+func TestTailDebugLogEvictsOldestFirst(t *testing.T) {
+	resetState()
+	defer resetState()
+
+	for i := 0; i < ringSize+10; i++ {
+		Infof("line %d", i)
+	}
+
+	tail := TailDebugLog(0)
+	lines := strings.Split(tail, "\n")
+	if len(lines) != ringSize {
+		t.Fatalf("TailDebugLog(0) returned %d lines, want the ring capped at %d", len(lines), ringSize)
+	}
+	if !strings.Contains(lines[0], fmt.Sprintf("line %d", 10)) {
+		t.Errorf("oldest retained line = %q, want it to start at line 10 once the first 10 are evicted", lines[0])
+	}
+	if !strings.Contains(lines[len(lines)-1], fmt.Sprintf("line %d", ringSize+9)) {
+		t.Errorf("newest line = %q, want the last one written", lines[len(lines)-1])
+	}
+}
+
+func TestTailDebugLogN(t *testing.T) {
+	resetState()
+	defer resetState()
+
+	for i := 0; i < 5; i++ {
+		Infof("line %d", i)
+	}
+
+	tail := TailDebugLog(2)
+	lines := strings.Split(tail, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("TailDebugLog(2) returned %d lines, want 2", len(lines))
+	}
+	if !strings.Contains(lines[len(lines)-1], "line 4") {
+		t.Errorf("last line = %q, want the most recently written one", lines[len(lines)-1])
+	}
+}
+
+func TestDebugfGatedBySetDebug(t *testing.T) {
+	resetState()
+	defer resetState()
+
+	Debugf("should not be recorded")
+	if got := TailDebugLog(0); got != "" {
+		t.Errorf("TailDebugLog(0) = %q, want empty since SetDebug was never called", got)
+	}
+
+	SetDebug()
+	Debugf("should be recorded")
+	if got := TailDebugLog(0); !strings.Contains(got, "should be recorded") {
+		t.Errorf("TailDebugLog(0) = %q, want it to contain the debug line logged after SetDebug", got)
+	}
+}
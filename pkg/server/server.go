@@ -0,0 +1,106 @@
+// Package server runs gptscript's HTTP daemon/server mode.
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/gptscript-ai/gptscript/pkg/gptscript"
+	"github.com/oklog/ulid/v2"
+)
+
+// runIDHeader is the header HTTP callers can use to correlate a response
+// with the run that produced it. A server process handles many concurrent
+// runs, so this is stamped per request rather than once for the process.
+const runIDHeader = "X-GPTScript-Run-Id"
+
+// Options configures the server.
+type Options struct {
+	ListenAddress string
+	GPTScript     gptscript.Options
+}
+
+// Server is a running (or not-yet-started) gptscript HTTP server.
+type Server struct {
+	opts     Options
+	listener net.Listener
+	http     *http.Server
+}
+
+// New prepares a Server but does not start listening until Start is called.
+func New(opts *Options) (*Server, error) {
+	s := &Server{opts: *opts}
+	s.http = &http.Server{
+		Handler: s.withRunID(http.DefaultServeMux),
+	}
+	return s, nil
+}
+
+// withRunID gives each request its own run ID and stamps it on the
+// response, so a caller following a specific run's events/logs across a
+// daemon handling many concurrent runs can tell its response apart from
+// everyone else's. A caller that already has a RunID (e.g. one it minted
+// when it queued the run) can send it back in the request's own
+// X-GPTScript-Run-Id header to have it echoed instead of a new one
+// being generated.
+func (s *Server) withRunID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runID := r.Header.Get(runIDHeader)
+		if runID == "" {
+			runID = ulid.Make().String()
+		}
+		w.Header().Set(runIDHeader, runID)
+		next.ServeHTTP(w, r.WithContext(withRunID(r.Context(), runID)))
+	})
+}
+
+type runIDKey struct{}
+
+// withRunID attaches runID to ctx so handlers further down the stack (e.g.
+// the ones that actually launch a run) can thread it into runner/monitor
+// options instead of generating their own.
+func withRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDKey{}, runID)
+}
+
+// RunIDFromContext returns the per-request run ID stamped by withRunID, or
+// "" if the request didn't go through this server's handler chain.
+func RunIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(runIDKey{}).(string)
+	return id
+}
+
+// Start listens on Options.ListenAddress and serves until ctx is done.
+func (s *Server) Start(ctx context.Context) error {
+	l, err := net.Listen("tcp", s.opts.ListenAddress)
+	if err != nil {
+		return err
+	}
+	s.listener = l
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.http.Serve(l)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return s.http.Close()
+	case err := <-errCh:
+		return err
+	}
+}
+
+// Close shuts the server down. If wait is true it blocks until in-flight
+// requests have drained.
+func (s *Server) Close(wait bool) {
+	if s.http == nil {
+		return
+	}
+	if wait {
+		_ = s.http.Shutdown(context.Background())
+	} else {
+		_ = s.http.Close()
+	}
+}